@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScrapeJob описывает одну задачу сканирования: с каких URL начинать,
+// какие селекторы использовать и куда складывать результат.
+type ScrapeJob struct {
+	Name           string   `yaml:"name"`
+	SeedURLs       []string `yaml:"seed_urls"`
+	AllowedDomains []string `yaml:"allowed_domains"`
+	TitleSelector  string   `yaml:"title_selector"`
+	LinkSelectors  []string `yaml:"link_selectors"`
+	MaxDepth       int      `yaml:"max_depth"`
+	URLFilters     []string `yaml:"url_filters"`
+	Parallelism    int      `yaml:"parallelism"`
+	// Delay, если задан, переопределяет общий флаг -rate и задаёт
+	// собственную скорость запросов на домен только для этой задачи.
+	Delay      Duration `yaml:"delay"`
+	UserAgent  string   `yaml:"user_agent"`
+	OutputPath string   `yaml:"output_path"`
+}
+
+// Config — корневая структура конфигурационного файла, описывающая
+// один или несколько ScrapeJob.
+type Config struct {
+	Jobs []ScrapeJob `yaml:"jobs"`
+}
+
+// Duration оборачивает time.Duration, чтобы значения вида "10ms" или "1s"
+// можно было задавать прямо в YAML-файле строкой.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	parsed, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return fmt.Errorf("не удалось разобрать длительность %q: %w", value.Value, err)
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// LoadConfig читает и разбирает YAML-файл конфигурации по пути path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать конфигурационный файл %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать конфигурационный файл %s: %w", path, err)
+	}
+
+	if len(cfg.Jobs) == 0 {
+		return nil, fmt.Errorf("конфигурационный файл %s не содержит ни одной задачи (jobs)", path)
+	}
+
+	for i := range cfg.Jobs {
+		if cfg.Jobs[i].Parallelism <= 0 {
+			cfg.Jobs[i].Parallelism = 3
+		}
+		if cfg.Jobs[i].TitleSelector == "" {
+			cfg.Jobs[i].TitleSelector = "h1#firstHeading"
+		}
+		if len(cfg.Jobs[i].LinkSelectors) == 0 {
+			cfg.Jobs[i].LinkSelectors = []string{"div.mw-body-content a"}
+		}
+		if cfg.Jobs[i].OutputPath == "" {
+			cfg.Jobs[i].OutputPath = "scraped_links.xlsx"
+		}
+	}
+
+	return &cfg, nil
+}
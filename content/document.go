@@ -0,0 +1,31 @@
+// Package content строит goquery.Document из сырого тела ответа (с учётом
+// кодировки страницы) и извлекает из него метаданные — основной текст
+// статьи, meta-описание, og:image и дату публикации — через набор
+// ContentExtractor.
+package content
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// NewDocument декодирует body в UTF-8 согласно заявленной в contentType
+// кодировке (или определённой эвристически, если заголовок её не содержит)
+// и строит из результата goquery.Document.
+func NewDocument(body []byte, contentType string) (*goquery.Document, error) {
+	utf8Reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось определить кодировку страницы: %w", err)
+	}
+
+	node, err := html.Parse(utf8Reader)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось разобрать HTML страницы: %w", err)
+	}
+
+	return goquery.NewDocumentFromNode(node), nil
+}
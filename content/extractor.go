@@ -0,0 +1,138 @@
+package content
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extractor достаёт из разобранной страницы одно строковое поле метаданных.
+type Extractor interface {
+	// Name — ключ поля, под которым значение попадает в Fields/вывод.
+	Name() string
+	Extract(doc *goquery.Document) string
+}
+
+// Fields — метаданные страницы, извлечённые встроенными Extractor'ами.
+type Fields struct {
+	ArticleText     string
+	MetaDescription string
+	OGImage         string
+	PublishDate     string
+}
+
+// Extract прогоняет doc через все встроенные экстракторы и возвращает
+// собранные поля.
+func Extract(doc *goquery.Document) Fields {
+	return Fields{
+		ArticleText:     articleTextExtractor{}.Extract(doc),
+		MetaDescription: metaDescriptionExtractor{}.Extract(doc),
+		OGImage:         ogImageExtractor{}.Extract(doc),
+		PublishDate:     publishDateExtractor{}.Extract(doc),
+	}
+}
+
+// articleTextExtractor вычищает служебные блоки (script/style/nav/footer) и
+// в духе Readability выбирает самый "плотный" по тексту article или div —
+// это и считается основным текстом статьи.
+type articleTextExtractor struct{}
+
+func (articleTextExtractor) Name() string { return "article_text" }
+
+// dominantChildRatio — если один потомок-кандидат содержит хотя бы такую
+// долю текста родителя, остальное в родителе — вёрстка/сайдбар вокруг него,
+// и в качестве статьи стоит взять именно этого потомка, а не родителя
+// целиком. Если текст размазан по нескольким более мелким потомкам ниже
+// этой доли (разметка вида div-на-абзац), ни один из них не является
+// статьёй сам по себе — тогда берётся родитель целиком.
+const dominantChildRatio = 0.6
+
+func (articleTextExtractor) Extract(doc *goquery.Document) string {
+	clone := doc.Clone()
+	clone.Find("script, style, nav, footer").Remove()
+
+	var densest string
+	var densestLen int
+	topLevelCandidates(clone).Each(func(_ int, s *goquery.Selection) {
+		text := candidateText(s)
+		if len(text) > densestLen {
+			densestLen = len(text)
+			densest = text
+		}
+	})
+	return densest
+}
+
+// topLevelCandidates возвращает кандидатов (article, div) верхнего уровня —
+// тех, среди предков которых нет другого кандидата. Вложенные кандидаты
+// обрабатываются рекурсивно внутри candidateText, а не как отдельные корни,
+// чтобы не оценивать одну и ту же статью дважды.
+func topLevelCandidates(root *goquery.Selection) *goquery.Selection {
+	return root.Find("article, div").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return s.ParentsFiltered("article, div").Length() == 0
+	})
+}
+
+// candidateText решает, взять ли текст узла целиком или спуститься к
+// самому текстовому из его прямых потомков-кандидатов — см. dominantChildRatio.
+func candidateText(s *goquery.Selection) string {
+	text := strings.TrimSpace(s.Text())
+
+	var bestChild string
+	s.ChildrenFiltered("article, div").Each(func(_ int, child *goquery.Selection) {
+		if childText := candidateText(child); len(childText) > len(bestChild) {
+			bestChild = childText
+		}
+	})
+
+	if len(bestChild) == 0 {
+		return text
+	}
+	if float64(len(bestChild)) >= dominantChildRatio*float64(len(text)) {
+		return bestChild
+	}
+	return text
+}
+
+// metaDescriptionExtractor читает <meta name="description">.
+type metaDescriptionExtractor struct{}
+
+func (metaDescriptionExtractor) Name() string { return "meta_description" }
+
+func (metaDescriptionExtractor) Extract(doc *goquery.Document) string {
+	content, _ := doc.Find(`meta[name="description"]`).First().Attr("content")
+	return content
+}
+
+// ogImageExtractor читает <meta property="og:image">.
+type ogImageExtractor struct{}
+
+func (ogImageExtractor) Name() string { return "og_image" }
+
+func (ogImageExtractor) Extract(doc *goquery.Document) string {
+	content, _ := doc.Find(`meta[property="og:image"]`).First().Attr("content")
+	return content
+}
+
+// publishDateExtractor перебирает самые частые места, где сайты публикуют
+// дату: OpenGraph/article meta-теги и <time datetime="...">.
+type publishDateExtractor struct{}
+
+func (publishDateExtractor) Name() string { return "publish_date" }
+
+func (publishDateExtractor) Extract(doc *goquery.Document) string {
+	selectors := []string{
+		`meta[property="article:published_time"]`,
+		`meta[name="date"]`,
+		`meta[name="publish-date"]`,
+	}
+	for _, selector := range selectors {
+		if content, ok := doc.Find(selector).First().Attr("content"); ok && content != "" {
+			return content
+		}
+	}
+	if datetime, ok := doc.Find("time[datetime]").First().Attr("datetime"); ok {
+		return datetime
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package content
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func mustDocument(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("не удалось разобрать фикстуру: %v", err)
+	}
+	return doc
+}
+
+func TestArticleTextExtractor_PrefersNestedArticleOverWrapper(t *testing.T) {
+	doc := mustDocument(t, `
+		<html><body>
+			<div id="page">
+				<div id="sidebar">Короткий текст сайдбара</div>
+				<article>Настоящий, гораздо более длинный текст статьи, который и должен быть выбран экстрактором как основной контент страницы.</article>
+			</div>
+		</body></html>
+	`)
+
+	got := articleTextExtractor{}.Extract(doc)
+	want := "Настоящий, гораздо более длинный текст статьи, который и должен быть выбран экстрактором как основной контент страницы."
+	if got != want {
+		t.Errorf("Extract() = %q, хотели %q", got, want)
+	}
+}
+
+func TestArticleTextExtractor_KeepsWholeArticleSplitAcrossParagraphDivs(t *testing.T) {
+	doc := mustDocument(t, `
+		<html><body>
+			<div id="article-body">
+				<div class="p">Первый абзац статьи, в котором закладывается основная тема материала и даётся вводный контекст для читателя.</div>
+				<div class="p">Второй абзац продолжает раскрывать тему и добавляет подробности, которые важны для полного понимания написанного.</div>
+				<div class="p">Третий, заключительный абзац подводит итог всему сказанному выше и завершает статью логичным выводом для читателя.</div>
+			</div>
+		</body></html>
+	`)
+
+	got := articleTextExtractor{}.Extract(doc)
+	for _, want := range []string{"Первый абзац", "Второй абзац", "Третий, заключительный абзац"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Extract() = %q, должен содержать %q (весь текст статьи, а не один абзац)", got, want)
+		}
+	}
+}
+
+func TestMetaDescriptionExtractor(t *testing.T) {
+	doc := mustDocument(t, `<html><head><meta name="description" content="тестовое описание"></head></html>`)
+	if got := (metaDescriptionExtractor{}).Extract(doc); got != "тестовое описание" {
+		t.Errorf("Extract() = %q, хотели %q", got, "тестовое описание")
+	}
+}
+
+func TestOGImageExtractor(t *testing.T) {
+	doc := mustDocument(t, `<html><head><meta property="og:image" content="https://example.com/image.png"></head></html>`)
+	if got := (ogImageExtractor{}).Extract(doc); got != "https://example.com/image.png" {
+		t.Errorf("Extract() = %q, хотели %q", got, "https://example.com/image.png")
+	}
+}
+
+func TestPublishDateExtractor(t *testing.T) {
+	cases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "article published_time",
+			html: `<html><head><meta property="article:published_time" content="2026-01-02T15:04:05Z"></head></html>`,
+			want: "2026-01-02T15:04:05Z",
+		},
+		{
+			name: "time datetime fallback",
+			html: `<html><body><time datetime="2026-03-04">4 марта</time></body></html>`,
+			want: "2026-03-04",
+		},
+		{
+			name: "nothing found",
+			html: `<html><body>без даты</body></html>`,
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := mustDocument(t, tc.html)
+			if got := (publishDateExtractor{}).Extract(doc); got != tc.want {
+				t.Errorf("Extract() = %q, хотели %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,122 +1,315 @@
-package main
-
-import (
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"sync"
-	"time"
-
-	"github.com/gocolly/colly/v2"
-	"github.com/xuri/excelize/v2"
-)
-
-type Link struct {
-	PageURL   string
-	PageTitle string
-	LinkURL   string
-}
-
-func main() {
-	//открытие файла для логов
-	file, err := os.OpenFile("parser.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		log.Fatalf("Не удалось открыть файл логов: %v", err)
-	}
-	//настройка multiwriter для одновременной записи и в терминал(logger) и в логи(файл)
-	mw := io.MultiWriter(os.Stdout, file)
-	logger := log.New(mw, "scraper: ", log.LstdFlags)
-
-	urls := []string{
-		"https://en.wikipedia.org/wiki/Dota_2",
-		"https://en.wikipedia.org/wiki/Counter-Strike:_Global_Offensive",
-		"https://en.wikipedia.org/wiki/Counter-Strike_2",
-		"https://en.wikipedia.org/wiki/Counter-Strike_(video_game)",
-	}
-
-	c := colly.NewCollector(
-		colly.Async(true),
-		colly.UserAgent("Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Mobile Safari/537.36"),
-	)
-
-	c.Limit(&colly.LimitRule{
-		DomainGlob:  "*",
-		Parallelism: 3,
-		Delay:       10 * time.Millisecond,
-	})
-
-	var allLinks []Link
-	var titles = make(map[string]string)
-	var mu sync.Mutex
-
-	c.OnRequest(func(r *colly.Request) {
-		logger.Println("Посещаем:", r.URL)
-	})
-
-	c.OnHTML("h1#firstHeading", func(e *colly.HTMLElement) {
-		mu.Lock()
-		titles[e.Request.URL.String()] = e.ChildText("i")
-		mu.Unlock()
-		logger.Println("Заголовок:", e.ChildText("i"))
-	})
-
-	c.OnHTML("div.mw-body-content a", func(h *colly.HTMLElement) {
-		link := h.Attr("href")
-		absoluteLink := h.Request.AbsoluteURL(link)
-		mu.Lock()
-		if absoluteLink != "" {
-			allLinks = append(allLinks, Link{
-				PageURL:   h.Request.URL.String(),
-				LinkURL:   absoluteLink,
-				PageTitle: titles[h.Request.URL.String()],
-			})
-		}
-		mu.Unlock()
-	})
-
-	c.OnError(func(r *colly.Response, err error) {
-		fmt.Println("Ошибка:", err)
-	})
-	for _, url := range urls {
-		err := c.Visit(url)
-		if err != nil {
-			logger.Printf("Не удалось посетить %s: %v\n", url, err)
-		}
-	}
-	c.Wait()
-	for i, link := range allLinks {
-		mu.Lock()
-		if title, exists := titles[link.PageURL]; exists {
-			allLinks[i].PageTitle = title
-		} else {
-			allLinks[i].PageTitle = "Неизвестный заголовок"
-		}
-		mu.Unlock()
-	}
-	logger.Printf("Количество ссылок: %d", len(allLinks))
-
-	//создаем excel-файл
-	f := excelize.NewFile()
-	defer f.Close()
-	sheet := "Результаты"
-	f.SetSheetName("Sheet1", sheet)
-
-	//устанавливаем заголовки таблицы
-	f.SetCellValue(sheet, "A1", "Page URL")
-	f.SetCellValue(sheet, "B1", "Page Title")
-	f.SetCellValue(sheet, "C1", "Link URL")
-
-	//заполняем таблицы данными
-	for i, link := range allLinks {
-		f.SetCellValue(sheet, fmt.Sprintf("A%d", i+2), link.PageURL)
-		f.SetCellValue(sheet, fmt.Sprintf("B%d", i+2), link.PageTitle)
-		f.SetCellValue(sheet, fmt.Sprintf("C%d", i+2), link.LinkURL)
-	}
-
-	//сохраняем экселевский файл
-	if err := f.SaveAs("scraped_links.xlsx"); err != nil {
-		logger.Fatalf("Ошибка при сохранении Excel-файла: %v", err)
-	}
-	logger.Println("Файл успешно сохранен")
-}
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	collyproxy "github.com/gocolly/colly/v2/proxy"
+
+	"github.com/sguschonka/go-colly---parser/content"
+	"github.com/sguschonka/go-colly---parser/netx"
+	"github.com/sguschonka/go-colly---parser/output"
+	scraperstorage "github.com/sguschonka/go-colly---parser/storage"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "путь к YAML-файлу конфигурации задач сканирования")
+	maxDepth := flag.Int("max-depth", 0, "ограничение глубины обхода (0 — использовать значение из конфигурации)")
+	sameDomainOnly := flag.Bool("same-domain-only", false, "для задач без allowed_domains переходить только по ссылкам в пределах доменов их стартовых URL")
+	storageKind := flag.String("storage", "memory", "бэкенд хранилища фронтира: memory, sqlite или redis")
+	storageDSN := flag.String("storage-dsn", "", "строка подключения к хранилищу (путь к файлу для sqlite, адрес для redis)")
+	resume := flag.Bool("resume", false, "продолжить обход с ранее сохранённого состояния вместо того, чтобы начинать заново")
+	format := flag.String("format", "", "формат вывода: xlsx, csv, jsonl, sqlite или parquet (по умолчанию определяется по расширению output_path)")
+	requestsPerSecond := flag.Float64("rate", 5, "ограничение запросов в секунду на домен")
+	maxRetries := flag.Int("max-retries", 3, "сколько раз повторять запрос при ответе 429 или 5xx")
+	proxiesFile := flag.String("proxies", "", "файл со списком прокси (по одному URL на строку); если пуст, используется переменная окружения PARSER_PROXIES")
+	metricsAddr := flag.String("metrics-addr", ":2112", "адрес эндпоинта /metrics с Prometheus-метриками (пусто — отключить)")
+	flag.Parse()
+
+	//открытие файла для логов
+	file, err := os.OpenFile("parser.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		log.Fatalf("Не удалось открыть файл логов: %v", err)
+	}
+	//настройка multiwriter для одновременной записи и в терминал(logger) и в логи(файл)
+	mw := io.MultiWriter(os.Stdout, file)
+	logger := log.New(mw, "scraper: ", log.LstdFlags)
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		logger.Fatalf("Не удалось загрузить конфигурацию: %v", err)
+	}
+
+	netx.ServeMetrics(*metricsAddr, logger)
+
+	proxies, err := netx.LoadProxies(*proxiesFile, "PARSER_PROXIES")
+	if err != nil {
+		logger.Fatalf("Не удалось загрузить список прокси: %v", err)
+	}
+
+	limiter := netx.NewDomainLimiter(*requestsPerSecond, 1)
+	retryPolicy := netx.NewRetryPolicy(*maxRetries)
+
+	for _, job := range cfg.Jobs {
+		if *maxDepth > 0 {
+			job.MaxDepth = *maxDepth
+		}
+		if *sameDomainOnly && len(job.AllowedDomains) == 0 {
+			job.AllowedDomains = seedDomains(job.SeedURLs, logger)
+		}
+
+		// job.Name изолирует состояние этой задачи внутри общего DSN
+		// (см. scraperstorage.New) — это нужно независимо от того, задан
+		// ли -storage-dsn явно, иначе задачи с общим DSN по умолчанию
+		// будут молча делить один visited-стор.
+		store, err := scraperstorage.New(*storageKind, *storageDSN, *resume, job.Name)
+		if err != nil {
+			logger.Printf("Задача %q: не удалось создать хранилище %q: %v", job.Name, *storageKind, err)
+			continue
+		}
+
+		writer, err := output.New(*format, job.OutputPath, *resume)
+		if err != nil {
+			logger.Printf("Задача %q: не удалось создать writer для %s: %v", job.Name, job.OutputPath, err)
+			continue
+		}
+
+		// job.Delay переопределяет общий -rate для задач, которым нужна
+		// своя скорость (например, более бережная к конкретному сайту);
+		// остальные задачи делят один лимитер, заданный флагом -rate
+		jobLimiter := limiter
+		if job.Delay.Duration > 0 {
+			jobLimiter = netx.NewDomainLimiter(1/job.Delay.Seconds(), 1)
+		}
+
+		if err := runJob(job, logger, store, writer, proxies, jobLimiter, retryPolicy); err != nil {
+			logger.Printf("Задача %q завершилась с ошибкой: %v", job.Name, err)
+		}
+		if err := writer.Close(); err != nil {
+			logger.Printf("Задача %q: не удалось закрыть writer: %v", job.Name, err)
+		}
+		if err := store.Close(); err != nil {
+			logger.Printf("Задача %q: не удалось закрыть хранилище: %v", job.Name, err)
+		}
+	}
+}
+
+// seedDomains извлекает уникальные хосты из списка стартовых URL — используется
+// флагом -same-domain-only, когда список allowed_domains не задан в конфигурации.
+func seedDomains(seedURLs []string, logger *log.Logger) []string {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, raw := range seedURLs {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			logger.Printf("Не удалось разобрать стартовый URL %s: %v", raw, err)
+			continue
+		}
+		if !seen[parsed.Host] {
+			seen[parsed.Host] = true
+			domains = append(domains, parsed.Host)
+		}
+	}
+	return domains
+}
+
+// runJob выполняет одну задачу сканирования: рекурсивно обходит её seed-URL
+// с учётом лимита глубины и белого списка доменов, ограничивая скорость на
+// домен через limiter, повторяя запросы по retryPolicy и потоково записывая
+// найденные ссылки через writer прямо из обработчиков OnHTML, не накапливая
+// их в памяти процесса.
+func runJob(job ScrapeJob, logger *log.Logger, store scraperstorage.Store, writer output.Writer, proxies []string, limiter *netx.DomainLimiter, retryPolicy netx.RetryPolicy) error {
+	logger.Printf("Запуск задачи %q: %d стартовых URL, max-depth=%d", job.Name, len(job.SeedURLs), job.MaxDepth)
+
+	userAgent := job.UserAgent
+	if userAgent == "" {
+		userAgent = "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Mobile Safari/537.36"
+	}
+
+	opts := []colly.CollectorOption{
+		colly.Async(true),
+		colly.UserAgent(userAgent),
+	}
+	if job.MaxDepth > 0 {
+		opts = append(opts, colly.MaxDepth(job.MaxDepth))
+	}
+	if len(job.AllowedDomains) > 0 {
+		opts = append(opts, colly.AllowedDomains(job.AllowedDomains...))
+	}
+	if len(job.URLFilters) > 0 {
+		filters := make([]*regexp.Regexp, 0, len(job.URLFilters))
+		for _, pattern := range job.URLFilters {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("некорректный url_filters паттерн %q: %w", pattern, err)
+			}
+			filters = append(filters, re)
+		}
+		opts = append(opts, colly.URLFilters(filters...))
+	}
+
+	c := colly.NewCollector(opts...)
+	c.IgnoreRobotsTxt = false
+	if err := c.SetStorage(store); err != nil {
+		return fmt.Errorf("не удалось подключить хранилище к коллектору: %w", err)
+	}
+
+	if len(proxies) > 0 {
+		switcher, err := collyproxy.RoundRobinProxySwitcher(proxies...)
+		if err != nil {
+			return fmt.Errorf("не удалось настроить ротацию прокси: %w", err)
+		}
+		c.SetProxyFunc(switcher)
+	}
+
+	// скорость запросов теперь регулирует per-domain token-bucket limiter в
+	// OnRequest, поэтому здесь остаётся только ограничение параллелизма
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: job.Parallelism,
+	})
+
+	if err := writer.WriteHeader(); err != nil {
+		return fmt.Errorf("не удалось записать заголовок вывода: %w", err)
+	}
+
+	var titles = make(map[string]string)
+	var pageContent = make(map[string]content.Fields)
+	var mu sync.Mutex
+	var visited sync.Map
+	var linkCount int
+
+	c.OnRequest(func(r *colly.Request) {
+		domain := r.URL.Host
+		if err := limiter.Wait(context.Background(), domain); err != nil {
+			logger.Printf("Лимитер отменил запрос %s: %v", r.URL, err)
+			r.Abort()
+			return
+		}
+
+		netx.RequestsTotal.WithLabelValues(domain).Inc()
+		netx.QueueDepth.WithLabelValues(domain).Inc()
+		r.Ctx.Put("netx_start", time.Now())
+		logger.Println("Посещаем:", r.URL)
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		domain := r.Request.URL.Host
+		netx.QueueDepth.WithLabelValues(domain).Dec()
+		if start, ok := r.Ctx.GetAny("netx_start").(time.Time); ok {
+			netx.RequestDuration.WithLabelValues(domain).Observe(time.Since(start).Seconds())
+		}
+
+		doc, err := content.NewDocument(r.Body, r.Headers.Get("Content-Type"))
+		if err != nil {
+			logger.Printf("Не удалось разобрать содержимое %s: %v", r.Request.URL, err)
+			return
+		}
+		fields := content.Extract(doc)
+		mu.Lock()
+		pageContent[r.Request.URL.String()] = fields
+		mu.Unlock()
+	})
+
+	c.OnHTML(job.TitleSelector, func(e *colly.HTMLElement) {
+		title := strings.TrimSpace(e.Text)
+		if title == "" {
+			// на некоторых сайтах (например, Википедии) сам текст
+			// заголовка лежит не прямо в совпавшем элементе, а в его
+			// дочернем <i> — это частный случай, а не общее правило
+			title = strings.TrimSpace(e.ChildText("i"))
+		}
+		mu.Lock()
+		titles[e.Request.URL.String()] = title
+		mu.Unlock()
+		logger.Println("Заголовок:", title)
+	})
+
+	for _, selector := range job.LinkSelectors {
+		c.OnHTML(selector, func(h *colly.HTMLElement) {
+			link := h.Attr("href")
+			absoluteLink := h.Request.AbsoluteURL(link)
+			if absoluteLink == "" {
+				return
+			}
+
+			pageURL := h.Request.URL.String()
+			mu.Lock()
+			title, known := titles[pageURL]
+			if !known {
+				title = "Неизвестный заголовок"
+			}
+			fields := pageContent[pageURL]
+			mu.Unlock()
+
+			// пишем ссылку сразу, как только она найдена, а не копим в
+			// allLinks для записи одним блоком в конце — для больших обходов
+			// это держит потребление памяти плоским
+			if err := writer.WriteLink(output.Link{
+				PageURL:         pageURL,
+				LinkURL:         absoluteLink,
+				PageTitle:       title,
+				Depth:           h.Request.Depth,
+				ArticleText:     fields.ArticleText,
+				MetaDescription: fields.MetaDescription,
+				OGImage:         fields.OGImage,
+				PublishDate:     fields.PublishDate,
+			}); err != nil {
+				logger.Printf("Не удалось записать ссылку %s: %v", absoluteLink, err)
+				return
+			}
+			mu.Lock()
+			linkCount++
+			mu.Unlock()
+
+			// обходим частоту посещений фронтира через sync.Map, чтобы не
+			// ставить одну и ту же ссылку на переобход много раз подряд
+			if _, alreadyQueued := visited.LoadOrStore(absoluteLink, true); !alreadyQueued {
+				if err := h.Request.Visit(absoluteLink); err != nil && err != colly.ErrAlreadyVisited {
+					logger.Printf("Не удалось поставить в очередь %s: %v", absoluteLink, err)
+				}
+			}
+		})
+	}
+
+	c.OnError(func(r *colly.Response, err error) {
+		domain := r.Request.URL.Host
+		netx.ErrorsTotal.WithLabelValues(domain).Inc()
+		netx.QueueDepth.WithLabelValues(domain).Dec()
+		logger.Printf("Ошибка %s (код %d): %v", r.Request.URL, r.StatusCode, err)
+
+		attempt, _ := r.Ctx.GetAny("retry_attempt").(int)
+		retry, delay := retryPolicy.Decide(r.StatusCode, attempt)
+		if !retry {
+			return
+		}
+
+		logger.Printf("Повтор %s через %s (попытка %d/%d)", r.Request.URL, delay, attempt+1, retryPolicy.MaxRetries)
+		time.Sleep(delay)
+		r.Ctx.Put("retry_attempt", attempt+1)
+		if err := r.Request.Retry(); err != nil {
+			logger.Printf("Не удалось повторить запрос %s: %v", r.Request.URL, err)
+		}
+	})
+
+	for _, seed := range job.SeedURLs {
+		visited.Store(seed, true)
+		if err := c.Visit(seed); err != nil {
+			logger.Printf("Не удалось посетить %s: %v\n", seed, err)
+		}
+	}
+	c.Wait()
+	logger.Printf("Количество ссылок: %d", linkCount)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package netx собирает сетевые заботы обхода, которые не относятся к самому
+// Colly: ограничение скорости на домен, ретраи с экспоненциальной задержкой
+// и экспорт метрик Prometheus. Держать их вместе с main упрощало демо на
+// одном сайте, но плохо масштабируется на много доменов и долгие прогоны.
+package netx
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// DomainLimiter — token-bucket ограничитель скорости запросов, отдельный для
+// каждого домена. В отличие от глобального colly.LimitRule{Delay: ...}, он
+// не душит быстрые домены задержкой, рассчитанной на медленные.
+type DomainLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	rps      rate.Limit
+	burst    int
+}
+
+// NewDomainLimiter создаёт лимитер, разрешающий requestsPerSecond запросов в
+// секунду (с всплеском до burst) на каждый домен.
+func NewDomainLimiter(requestsPerSecond float64, burst int) *DomainLimiter {
+	return &DomainLimiter{
+		limiters: make(map[string]*rate.Limiter),
+		rps:      rate.Limit(requestsPerSecond),
+		burst:    burst,
+	}
+}
+
+// Wait блокируется, пока домену host не будет выделен токен.
+func (l *DomainLimiter) Wait(ctx context.Context, host string) error {
+	return l.limiterFor(host).Wait(ctx)
+}
+
+func (l *DomainLimiter) limiterFor(host string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lim, ok := l.limiters[host]
+	if !ok {
+		lim = rate.NewLimiter(l.rps, l.burst)
+		l.limiters[host] = lim
+	}
+	return lim
+}
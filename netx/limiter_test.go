@@ -0,0 +1,51 @@
+package netx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDomainLimiter_WaitBlocksUntilTokenAvailable(t *testing.T) {
+	l := NewDomainLimiter(10, 1)
+
+	if err := l.Wait(context.Background(), "example.com"); err != nil {
+		t.Fatalf("first Wait() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background(), "example.com"); err != nil {
+		t.Fatalf("second Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second Wait() returned after %v, expected to block roughly 1/10s for the next token", elapsed)
+	}
+}
+
+func TestDomainLimiter_DomainsAreIndependent(t *testing.T) {
+	l := NewDomainLimiter(1, 1)
+
+	if err := l.Wait(context.Background(), "a.example.com"); err != nil {
+		t.Fatalf("Wait(a) error = %v", err)
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background(), "b.example.com"); err != nil {
+		t.Fatalf("Wait(b) error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Wait() for a different domain took %v, should not share a's bucket", elapsed)
+	}
+}
+
+func TestDomainLimiter_WaitRespectsContextCancellation(t *testing.T) {
+	l := NewDomainLimiter(1, 1)
+	_ = l.Wait(context.Background(), "example.com")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.Wait(ctx, "example.com"); err == nil {
+		t.Error("Wait() with a cancelled context should return an error")
+	}
+}
@@ -0,0 +1,57 @@
+package netx
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Метрики обхода, с разбивкой по домену, чтобы долгий прогон на много
+// доменов можно было наблюдать так же, как советуют доки Colly для
+// production-сканеров.
+var (
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "colly_parser_requests_total",
+		Help: "Количество запросов, отправленных коллектором, по доменам.",
+	}, []string{"domain"})
+
+	ErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "colly_parser_errors_total",
+		Help: "Количество неудачных запросов, по доменам.",
+	}, []string{"domain"})
+
+	QueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "colly_parser_queue_depth",
+		Help: "Количество запросов в полёте (отправлены, но ответ ещё не обработан), по доменам.",
+	}, []string{"domain"})
+
+	RequestDuration = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "colly_parser_request_duration_seconds",
+		Help:       "Время ответа на запрос, по доменам.",
+		Objectives: map[float64]float64{0.5: 0.05, 0.95: 0.01},
+	}, []string{"domain"})
+)
+
+func init() {
+	prometheus.MustRegister(RequestsTotal, ErrorsTotal, QueueDepth, RequestDuration)
+}
+
+// ServeMetrics поднимает HTTP-сервер с эндпоинтом /metrics в отдельной
+// горутине. Пустой addr отключает экспорт метрик.
+func ServeMetrics(addr string, logger *log.Logger) {
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logger.Printf("Сервер метрик на %s остановлен: %v", addr, err)
+		}
+	}()
+	logger.Printf("Метрики доступны на http://%s/metrics", addr)
+}
@@ -0,0 +1,51 @@
+package netx
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadProxies читает список прокси (по одному URL на строку, пустые строки и
+// строки, начинающиеся с "#", пропускаются) из файла path. Если path пуст,
+// возвращает список из переменной окружения envVar, разделённый запятыми.
+func LoadProxies(path, envVar string) ([]string, error) {
+	if path == "" {
+		return splitEnvList(os.Getenv(envVar)), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть файл со списком прокси %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var proxies []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		proxies = append(proxies, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл со списком прокси %s: %w", path, err)
+	}
+	return proxies, nil
+}
+
+func splitEnvList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var proxies []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			proxies = append(proxies, part)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,30 @@
+package netx
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy описывает, при каких статус-кодах и с какой экспоненциальной
+// задержкой запрос стоит повторить.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// NewRetryPolicy создаёт политику с разумной по умолчанию базовой задержкой.
+func NewRetryPolicy(maxRetries int) RetryPolicy {
+	return RetryPolicy{MaxRetries: maxRetries, BaseDelay: 500 * time.Millisecond}
+}
+
+// Decide сообщает, стоит ли повторять запрос с данным statusCode на попытке
+// attempt (попытки нумеруются с 0), и через какую задержку это сделать.
+func (p RetryPolicy) Decide(statusCode, attempt int) (retry bool, delay time.Duration) {
+	if attempt >= p.MaxRetries {
+		return false, 0
+	}
+	if statusCode != http.StatusTooManyRequests && statusCode < 500 {
+		return false, 0
+	}
+	return true, p.BaseDelay * (1 << attempt)
+}
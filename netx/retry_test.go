@@ -0,0 +1,36 @@
+package netx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Decide(t *testing.T) {
+	p := NewRetryPolicy(3)
+
+	cases := []struct {
+		name       string
+		statusCode int
+		attempt    int
+		wantRetry  bool
+		wantDelay  time.Duration
+	}{
+		{"429 first attempt", http.StatusTooManyRequests, 0, true, p.BaseDelay},
+		{"503 second attempt backs off", http.StatusServiceUnavailable, 1, true, p.BaseDelay * 2},
+		{"4xx other than 429 never retried", http.StatusNotFound, 0, false, 0},
+		{"exhausted retries", http.StatusServiceUnavailable, 3, false, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			retry, delay := p.Decide(tc.statusCode, tc.attempt)
+			if retry != tc.wantRetry {
+				t.Errorf("Decide() retry = %v, want %v", retry, tc.wantRetry)
+			}
+			if delay != tc.wantDelay {
+				t.Errorf("Decide() delay = %v, want %v", delay, tc.wantDelay)
+			}
+		})
+	}
+}
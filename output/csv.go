@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// csvWriter пишет строки напрямую в *os.File через bufio-буферизованный
+// encoding/csv.Writer, без промежуточного накопления строк в памяти.
+type csvWriter struct {
+	mu sync.Mutex
+	f  *os.File
+	w  *csv.Writer
+}
+
+func newCSVWriter(path string) (*csvWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать CSV-файл %s: %w", path, err)
+	}
+	return &csvWriter{f: f, w: csv.NewWriter(f)}, nil
+}
+
+func (w *csvWriter) WriteHeader() error {
+	return w.w.Write([]string{
+		"Page URL", "Page Title", "Link URL", "Depth",
+		"Article Text", "Meta Description", "OG Image", "Publish Date",
+	})
+}
+
+func (w *csvWriter) WriteLink(link Link) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write([]string{
+		link.PageURL, link.PageTitle, link.LinkURL, strconv.Itoa(link.Depth),
+		link.ArticleText, link.MetaDescription, link.OGImage, link.PublishDate,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		w.f.Close()
+		return fmt.Errorf("не удалось записать CSV-файл: %w", err)
+	}
+	return w.f.Close()
+}
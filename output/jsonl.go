@@ -0,0 +1,41 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// jsonlWriter пишет одну ссылку на строку в формате newline-delimited JSON —
+// удобно для потоковой обработки результата другими инструментами без
+// загрузки всего файла целиком.
+type jsonlWriter struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+func newJSONLWriter(path string) (*jsonlWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать JSONL-файл %s: %w", path, err)
+	}
+	return &jsonlWriter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (w *jsonlWriter) WriteHeader() error {
+	return nil
+}
+
+func (w *jsonlWriter) WriteLink(link Link) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(link)
+}
+
+func (w *jsonlWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/segmentio/parquet-go"
+)
+
+// parquetRow — представление Link со схемой для parquet.NewGenericWriter.
+type parquetRow struct {
+	PageURL         string `parquet:"page_url"`
+	PageTitle       string `parquet:"page_title"`
+	LinkURL         string `parquet:"link_url"`
+	Depth           int    `parquet:"depth"`
+	ArticleText     string `parquet:"article_text"`
+	MetaDescription string `parquet:"meta_description"`
+	OGImage         string `parquet:"og_image"`
+	PublishDate     string `parquet:"publish_date"`
+}
+
+// parquetWriter буферизует строки в колоночные row-group'ы parquet.Writer и
+// сбрасывает их на диск по мере заполнения, не требуя держать весь датасет
+// в памяти.
+type parquetWriter struct {
+	mu sync.Mutex
+	f  *os.File
+	w  *parquet.GenericWriter[parquetRow]
+}
+
+func newParquetWriter(path string) (*parquetWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать parquet-файл %s: %w", path, err)
+	}
+	return &parquetWriter{f: f, w: parquet.NewGenericWriter[parquetRow](f)}, nil
+}
+
+func (w *parquetWriter) WriteHeader() error {
+	return nil
+}
+
+func (w *parquetWriter) WriteLink(link Link) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.w.Write([]parquetRow{{
+		PageURL:         link.PageURL,
+		PageTitle:       link.PageTitle,
+		LinkURL:         link.LinkURL,
+		Depth:           link.Depth,
+		ArticleText:     link.ArticleText,
+		MetaDescription: link.MetaDescription,
+		OGImage:         link.OGImage,
+		PublishDate:     link.PublishDate,
+	}})
+	return err
+}
+
+func (w *parquetWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.w.Close(); err != nil {
+		w.f.Close()
+		return fmt.Errorf("не удалось завершить запись parquet: %w", err)
+	}
+	return w.f.Close()
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteWriter пишет ссылки в таблицу links файла SQLite по одной строке за
+// раз, что позволяет выгружать результат, не помещая его целиком в память.
+type sqliteWriter struct {
+	mu sync.Mutex
+	db *sql.DB
+}
+
+func newSQLiteWriter(path string) (*sqliteWriter, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть sqlite-файл %s: %w", path, err)
+	}
+	return &sqliteWriter{db: db}, nil
+}
+
+func (w *sqliteWriter) WriteHeader() error {
+	_, err := w.db.Exec(`
+		DROP TABLE IF EXISTS links;
+		CREATE TABLE links (
+			page_url TEXT,
+			page_title TEXT,
+			link_url TEXT,
+			depth INTEGER,
+			article_text TEXT,
+			meta_description TEXT,
+			og_image TEXT,
+			publish_date TEXT
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("не удалось создать таблицу links: %w", err)
+	}
+	return nil
+}
+
+func (w *sqliteWriter) WriteLink(link Link) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.db.Exec(
+		`INSERT INTO links (page_url, page_title, link_url, depth, article_text, meta_description, og_image, publish_date)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		link.PageURL, link.PageTitle, link.LinkURL, link.Depth,
+		link.ArticleText, link.MetaDescription, link.OGImage, link.PublishDate,
+	)
+	return err
+}
+
+func (w *sqliteWriter) Close() error {
+	return w.db.Close()
+}
@@ -0,0 +1,111 @@
+// Package output предоставляет сохраняемые форматы результата сканирования
+// (xlsx, CSV, JSONL, SQLite, Parquet) за единым интерфейсом Writer, так что
+// ссылки можно записывать потоково по мере обхода, а не копить в памяти и
+// сбрасывать на диск одним блоком в конце.
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Link — одна найденная ссылка вместе со страницей, на которой она найдена,
+// и метаданными, извлечёнными из этой страницы пакетом content.
+type Link struct {
+	PageURL         string
+	PageTitle       string
+	LinkURL         string
+	Depth           int
+	ArticleText     string
+	MetaDescription string
+	OGImage         string
+	PublishDate     string
+}
+
+// Writer потоково записывает результат сканирования в конкретный формат.
+// WriteLink вызывается из обработчиков Colly по мере обхода и должен быть
+// безопасен для конкурентного вызова.
+type Writer interface {
+	WriteHeader() error
+	WriteLink(link Link) error
+	Close() error
+}
+
+// New создаёт Writer по имени формата (xlsx, csv, jsonl, sqlite, parquet) и
+// пути к файлу. Если format пуст, формат определяется по расширению path.
+//
+// Ни один из форматов ниже не умеет дописывать строки в уже существующий
+// файл, не перечитывая его целиком, поэтому при resume=true New не
+// перезаписывает файл прошлого запуска молча: он откладывает его в сторону
+// (path + ".prev", ".prev2", ...), а новый Writer пишет с нуля в path.
+// Так результат прерванного запуска не теряется, даже если сам фронтир
+// (см. storage.New) продолжает обход с того места, где остановился.
+func New(format, path string, resume bool) (Writer, error) {
+	if format == "" {
+		format = formatFromExt(path)
+	}
+
+	if resume {
+		if err := preserveExisting(path); err != nil {
+			return nil, err
+		}
+	}
+
+	switch format {
+	case "xlsx":
+		return newXLSXWriter(path)
+	case "csv":
+		return newCSVWriter(path)
+	case "jsonl":
+		return newJSONLWriter(path)
+	case "sqlite":
+		return newSQLiteWriter(path)
+	case "parquet":
+		return newParquetWriter(path)
+	default:
+		return nil, fmt.Errorf("неизвестный формат вывода %q (ожидается xlsx, csv, jsonl, sqlite или parquet)", format)
+	}
+}
+
+// preserveExisting откладывает уже существующий по пути path файл в
+// сторону, чтобы последующий New() с тем же path начинал писать новый файл
+// с нуля, не уничтожая результат предыдущего (прерванного) запуска.
+func preserveExisting(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("не удалось проверить существующий файл вывода %s: %w", path, err)
+	}
+
+	backup := path + ".prev"
+	for i := 2; fileExists(backup); i++ {
+		backup = fmt.Sprintf("%s.prev%d", path, i)
+	}
+	if err := os.Rename(path, backup); err != nil {
+		return fmt.Errorf("не удалось сохранить результат прерванного запуска %s: %w", path, err)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func formatFromExt(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	case ".db", ".sqlite", ".sqlite3":
+		return "sqlite"
+	case ".parquet":
+		return "parquet"
+	default:
+		return "xlsx"
+	}
+}
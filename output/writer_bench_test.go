@@ -0,0 +1,58 @@
+package output
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func benchmarkLink(i int) Link {
+	return Link{
+		PageURL:   "https://en.wikipedia.org/wiki/Example",
+		PageTitle: "Example",
+		LinkURL:   "https://en.wikipedia.org/wiki/Link",
+		Depth:     i % 5,
+	}
+}
+
+// BenchmarkCSVWriter_WriteLink меряет стоимость потоковой записи строк в CSV,
+// чтобы её можно было сравнить с буферизацией всего результата в памяти.
+func BenchmarkCSVWriter_WriteLink(b *testing.B) {
+	w, err := newCSVWriter(filepath.Join(b.TempDir(), "bench.csv"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer w.Close()
+
+	if err := w.WriteHeader(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := w.WriteLink(benchmarkLink(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkXLSXWriter_WriteLink меряет стоимость потоковой записи строк через
+// excelize.StreamWriter — тот же сценарий, что и раньше собирался в срез
+// allLinks и писался в конце одним блоком.
+func BenchmarkXLSXWriter_WriteLink(b *testing.B) {
+	w, err := newXLSXWriter(filepath.Join(b.TempDir(), "bench.xlsx"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer w.Close()
+
+	if err := w.WriteHeader(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := w.WriteLink(benchmarkLink(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxWriter пишет строки через excelize.StreamWriter, что держит в памяти
+// только текущую строку вместо всей книги — для сотен тысяч ссылок это
+// критично для времени записи и потребления RAM.
+type xlsxWriter struct {
+	mu   sync.Mutex
+	f    *excelize.File
+	sw   *excelize.StreamWriter
+	path string
+	row  int
+}
+
+func newXLSXWriter(path string) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	sheet := "Результаты"
+	f.SetSheetName("Sheet1", sheet)
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("не удалось создать потоковую запись xlsx: %w", err)
+	}
+
+	return &xlsxWriter{f: f, sw: sw, path: path, row: 1}, nil
+}
+
+func (w *xlsxWriter) WriteHeader() error {
+	cell, _ := excelize.CoordinatesToCellName(1, w.row)
+	return w.sw.SetRow(cell, []interface{}{
+		"Page URL", "Page Title", "Link URL", "Depth",
+		"Article Text", "Meta Description", "OG Image", "Publish Date",
+	})
+}
+
+func (w *xlsxWriter) WriteLink(link Link) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.row++
+	cell, _ := excelize.CoordinatesToCellName(1, w.row)
+	return w.sw.SetRow(cell, []interface{}{
+		link.PageURL, link.PageTitle, link.LinkURL, link.Depth,
+		link.ArticleText, link.MetaDescription, link.OGImage, link.PublishDate,
+	})
+}
+
+func (w *xlsxWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.sw.Flush(); err != nil {
+		w.f.Close()
+		return fmt.Errorf("не удалось сбросить потоковую запись xlsx: %w", err)
+	}
+	if err := w.f.SaveAs(w.path); err != nil {
+		w.f.Close()
+		return fmt.Errorf("ошибка при сохранении Excel-файла %s: %w", w.path, err)
+	}
+	return w.f.Close()
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStorage хранит фронтир и куки в Redis, что позволяет нескольким
+// процессам сканирования делить одно состояние и переживать перезапуск
+// (см. флаг -resume в main). Ключи именуются по задаче (prefix включает
+// jobName), чтобы несколько задач могли делить один DSN по умолчанию, не
+// видя "посещённые" ссылки друг друга.
+type redisStorage struct {
+	client *redis.Client
+	ctx    context.Context
+	prefix string
+}
+
+func newRedisStorage(dsn string, resume bool, jobName string) (*redisStorage, error) {
+	if dsn == "" {
+		dsn = "redis://127.0.0.1:6379/0"
+	}
+
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный redis DSN %s: %w", dsn, err)
+	}
+
+	s := &redisStorage{
+		client: redis.NewClient(opts),
+		ctx:    context.Background(),
+		prefix: "colly-parser:" + namespaceFor(jobName) + ":",
+	}
+
+	if !resume {
+		if err := s.reset(); err != nil {
+			return nil, fmt.Errorf("не удалось очистить состояние redis перед новым обходом: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *redisStorage) key(name string) string {
+	return s.prefix + name
+}
+
+func (s *redisStorage) reset() error {
+	return s.client.Del(s.ctx, s.key("visited"), s.key("cookies")).Err()
+}
+
+// Init реализует colly/storage.Storage.
+func (s *redisStorage) Init() error {
+	return s.client.Ping(s.ctx).Err()
+}
+
+// Visited реализует colly/storage.Storage.
+func (s *redisStorage) Visited(requestID uint64) error {
+	return s.client.SAdd(s.ctx, s.key("visited"), requestID).Err()
+}
+
+// IsVisited реализует colly/storage.Storage.
+func (s *redisStorage) IsVisited(requestID uint64) (bool, error) {
+	return s.client.SIsMember(s.ctx, s.key("visited"), requestID).Result()
+}
+
+// Cookies реализует colly/storage.Storage.
+func (s *redisStorage) Cookies(u *url.URL) string {
+	value, _ := s.client.HGet(s.ctx, s.key("cookies"), u.Host).Result()
+	return value
+}
+
+// SetCookies реализует colly/storage.Storage.
+func (s *redisStorage) SetCookies(u *url.URL, cookies string) {
+	s.client.HSet(s.ctx, s.key("cookies"), u.Host, cookies)
+}
+
+// Close закрывает соединение с Redis.
+func (s *redisStorage) Close() error {
+	return s.client.Close()
+}
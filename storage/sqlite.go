@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteStorage хранит фронтир (посещённые URL) и куки в файле SQLite, что
+// делает обход переживаемым перезапуск процесса (см. флаг -resume в main).
+// Таблицы именуются по задаче (visitedTable/cookiesTable), чтобы несколько
+// задач в одной конфигурации могли делить один DSN по умолчанию, не видя
+// "посещённые" ссылки друг друга.
+type sqliteStorage struct {
+	db           *sql.DB
+	visitedTable string
+	cookiesTable string
+}
+
+func newSQLiteStorage(dsn string, resume bool, jobName string) (*sqliteStorage, error) {
+	if dsn == "" {
+		dsn = "scraper_state.db"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть sqlite-файл %s: %w", dsn, err)
+	}
+
+	ns := namespaceFor(jobName)
+	s := &sqliteStorage{
+		db:           db,
+		visitedTable: "visited_" + ns,
+		cookiesTable: "cookies_" + ns,
+	}
+	if err := s.init(resume); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *sqliteStorage) init(resume bool) error {
+	if !resume {
+		if _, err := s.db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s; DROP TABLE IF EXISTS %s;`, s.visitedTable, s.cookiesTable)); err != nil {
+			return fmt.Errorf("не удалось очистить состояние sqlite перед новым обходом: %w", err)
+		}
+	}
+
+	_, err := s.db.Exec(fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY);
+		CREATE TABLE IF NOT EXISTS %s (host TEXT PRIMARY KEY, value TEXT);
+	`, s.visitedTable, s.cookiesTable))
+	if err != nil {
+		return fmt.Errorf("не удалось создать таблицы sqlite: %w", err)
+	}
+	return nil
+}
+
+// Init реализует colly/storage.Storage.
+func (s *sqliteStorage) Init() error {
+	return nil
+}
+
+// Visited реализует colly/storage.Storage: отмечает requestID посещённым.
+func (s *sqliteStorage) Visited(requestID uint64) error {
+	_, err := s.db.Exec(fmt.Sprintf(`INSERT OR IGNORE INTO %s (id) VALUES (?)`, s.visitedTable), int64(requestID))
+	return err
+}
+
+// IsVisited реализует colly/storage.Storage.
+func (s *sqliteStorage) IsVisited(requestID uint64) (bool, error) {
+	var id int64
+	err := s.db.QueryRow(fmt.Sprintf(`SELECT id FROM %s WHERE id = ?`, s.visitedTable), int64(requestID)).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Cookies реализует colly/storage.Storage.
+func (s *sqliteStorage) Cookies(u *url.URL) string {
+	var value string
+	_ = s.db.QueryRow(fmt.Sprintf(`SELECT value FROM %s WHERE host = ?`, s.cookiesTable), u.Host).Scan(&value)
+	return value
+}
+
+// SetCookies реализует colly/storage.Storage.
+func (s *sqliteStorage) SetCookies(u *url.URL, cookies string) {
+	_, _ = s.db.Exec(fmt.Sprintf(`INSERT INTO %s (host, value) VALUES (?, ?) ON CONFLICT(host) DO UPDATE SET value = excluded.value`, s.cookiesTable), u.Host, cookies)
+}
+
+// Close закрывает файл SQLite.
+func (s *sqliteStorage) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteStorage_VisitedRoundTrip(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "state.db")
+
+	s, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage() error = %v", err)
+	}
+	defer s.Close()
+
+	const requestID = uint64(42)
+	if visited, err := s.IsVisited(requestID); err != nil || visited {
+		t.Fatalf("IsVisited() before Visited() = %v, %v; want false, nil", visited, err)
+	}
+
+	if err := s.Visited(requestID); err != nil {
+		t.Fatalf("Visited() error = %v", err)
+	}
+	if visited, err := s.IsVisited(requestID); err != nil || !visited {
+		t.Fatalf("IsVisited() after Visited() = %v, %v; want true, nil", visited, err)
+	}
+}
+
+func TestSQLiteStorage_ResumeKeepsVisitedState(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "state.db")
+
+	s1, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage() error = %v", err)
+	}
+	if err := s1.Visited(7); err != nil {
+		t.Fatalf("Visited() error = %v", err)
+	}
+	if err := s1.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	s2, err := newSQLiteStorage(dsn, true, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage(resume=true) error = %v", err)
+	}
+	defer s2.Close()
+
+	if visited, err := s2.IsVisited(7); err != nil || !visited {
+		t.Fatalf("IsVisited() after resume = %v, %v; want true, nil", visited, err)
+	}
+}
+
+func TestSQLiteStorage_NoResumeDropsVisitedState(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "state.db")
+
+	s1, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage() error = %v", err)
+	}
+	if err := s1.Visited(7); err != nil {
+		t.Fatalf("Visited() error = %v", err)
+	}
+	if err := s1.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	s2, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage(resume=false) error = %v", err)
+	}
+	defer s2.Close()
+
+	if visited, err := s2.IsVisited(7); err != nil || visited {
+		t.Fatalf("IsVisited() after fresh start = %v, %v; want false, nil", visited, err)
+	}
+}
+
+func TestSQLiteStorage_JobsSharingDSNAreIsolated(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "state.db")
+
+	jobA, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage(job-a) error = %v", err)
+	}
+	defer jobA.Close()
+
+	jobB, err := newSQLiteStorage(dsn, false, "job-b")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage(job-b) error = %v", err)
+	}
+	defer jobB.Close()
+
+	if err := jobA.Visited(99); err != nil {
+		t.Fatalf("Visited() error = %v", err)
+	}
+	if visited, err := jobB.IsVisited(99); err != nil || visited {
+		t.Fatalf("IsVisited() in job-b = %v, %v; want false, nil — jobs sharing a DSN must not share visited state", visited, err)
+	}
+}
+
+func TestSQLiteStorage_Cookies(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "state.db")
+
+	s, err := newSQLiteStorage(dsn, false, "job-a")
+	if err != nil {
+		t.Fatalf("newSQLiteStorage() error = %v", err)
+	}
+	defer s.Close()
+
+	u := &url.URL{Host: "example.com"}
+	s.SetCookies(u, "session=abc")
+	if got := s.Cookies(u); got != "session=abc" {
+		t.Errorf("Cookies() = %q, want %q", got, "session=abc")
+	}
+}
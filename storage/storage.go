@@ -0,0 +1,64 @@
+// Package storage предоставляет подключаемые бэкенды для хранения состояния
+// обхода Colly — посещённых URL и куки. Это позволяет прерванному
+// сканированию продолжиться с того же места (флаг -resume) вместо того,
+// чтобы начинать фронтир с нуля.
+package storage
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	collystorage "github.com/gocolly/colly/v2/storage"
+)
+
+// Store расширяет colly/storage.Storage методом Close, чтобы вызывающий код
+// мог освобождать файловые дескрипторы sqlite и соединения redis по
+// завершении задачи, а не держать их открытыми до конца процесса.
+type Store interface {
+	collystorage.Storage
+	io.Closer
+}
+
+// memoryStore оборачивает InMemoryStorage, которому закрывать нечего, но
+// который всё равно должен удовлетворять интерфейсу Store.
+type memoryStore struct {
+	collystorage.InMemoryStorage
+}
+
+func (memoryStore) Close() error { return nil }
+
+// New создаёт colly-хранилище по имени бэкенда (memory, sqlite, redis) и
+// строке подключения dsn. Если resume равен false, ранее сохранённое
+// состояние отбрасывается и обход начинается заново. jobName изолирует
+// состояние одной задачи от другой внутри общего DSN (sqlite-таблицы и
+// redis-ключи получают его в качестве неймспейса) — это нужно всегда, а не
+// только когда пользователь сам задал разные -storage-dsn для разных задач,
+// иначе задачи с пересекающимися URL будут видеть чужие "посещённые" ссылки.
+func New(kind, dsn string, resume bool, jobName string) (Store, error) {
+	switch kind {
+	case "", "memory":
+		return &memoryStore{}, nil
+	case "sqlite":
+		return newSQLiteStorage(dsn, resume, jobName)
+	case "redis":
+		return newRedisStorage(dsn, resume, jobName)
+	default:
+		return nil, fmt.Errorf("неизвестный тип хранилища %q (ожидается memory, sqlite или redis)", kind)
+	}
+}
+
+var unsafeIdentChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// namespaceFor приводит имя задачи к безопасному для использования в
+// SQL-идентификаторе или redis-ключе виду, заменяя недопустимые символы на
+// "_". Пустое имя задачи получает фиксированный неймспейс "default", так что
+// все задачи без имени (как и задачи, чьи имена совпадают после замены
+// символов) делят одно и то же состояние.
+func namespaceFor(jobName string) string {
+	ns := unsafeIdentChars.ReplaceAllString(jobName, "_")
+	if ns == "" {
+		return "default"
+	}
+	return ns
+}